@@ -92,9 +92,11 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 	"io"
 	"regexp"
+	"strings"
 )
 
 type status string
@@ -114,6 +116,30 @@ func NewStatus(s string) status {
 	return status(s)
 }
 
+// Error satisfies the error interface so a status can be used as the
+// target in errors.Is and errors.HasStatus.
+func (s status) Error() string { return string(s) }
+
+// statuser is implemented by every error type in this package that
+// carries a status. It is not exported, but used by StatusLine, Is and
+// HasStatus to walk an error chain without a type switch per caller.
+type statuser interface {
+	Status() status
+}
+
+// messenger is implemented by every error type in this package, giving
+// access to its own message without the status prefix or the text of any
+// wrapped cause.
+type messenger interface {
+	Message() string
+}
+
+// stackTracer is implemented by any error that recorded a stack trace;
+// see the package doc comment above.
+type stackTracer interface {
+	StackTrace() StackTrace
+}
+
 // New returns an error with the supplied message.
 // New also records the stack trace at the point it was called.
 
@@ -143,6 +169,10 @@ type fundamental struct {
 	*stack
 }
 
+// Message returns the message carried by this error, without its status
+// or the text of any wrapped cause.
+func (f *fundamental) Message() string { return f.msg }
+
 func (f *fundamental) Error() string {
 	s := string(f.code)
 	if f.msg != "" {
@@ -152,6 +182,12 @@ func (f *fundamental) Error() string {
 
 }
 
+// Status returns the status code carried by this error.
+func (f *fundamental) Status() status { return f.code }
+
+// Unwrap returns nil: a fundamental is always the root of a chain.
+func (f *fundamental) Unwrap() error { return nil }
+
 func (f *fundamental) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
@@ -257,6 +293,17 @@ func (w *withMessage) Error() string {
 
 func (w *withMessage) Cause() error { return w.cause }
 
+// Unwrap returns the next error in the chain, for use by the standard
+// library errors.Is, errors.As and errors.Unwrap.
+func (w *withMessage) Unwrap() error { return w.cause }
+
+// Status returns the status code carried by this error.
+func (w *withMessage) Status() status { return w.code }
+
+// Message returns the message carried by this error, without its status
+// or the text of the wrapped cause.
+func (w *withMessage) Message() string { return w.msg }
+
 func (w *withMessage) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
@@ -297,29 +344,92 @@ func Cause(err error) error {
 	return err
 }
 
+// walkChain calls visit for err and every error reachable from it via
+// Unwrap, stopping as soon as visit returns true or the chain ends. A
+// *MultiError node is not itself visited; walkChain recurses into each of
+// its aggregated errors instead, in order, stopping at the first one
+// whose own walk is satisfied by visit, so HasStatus, lookupMeta and
+// Marshal see the statuses, stacks and messages of every error it
+// carries, and status-metadata lookups are "outermost wins" consistent
+// with Cause(multi) returning m.errs[0].
+func walkChain(err error, visit func(error) bool) bool {
+	for err != nil {
+		if m, ok := err.(*MultiError); ok {
+			for _, e := range m.errs {
+				if walkChain(e, visit) {
+					return true
+				}
+			}
+			return false
+		}
+		if visit(err) {
+			return true
+		}
+		err = stderrors.Unwrap(err)
+	}
+	return false
+}
+
+// StatusLine renders the dotted chain of statuses carried by err, outermost
+// first (e.g. "NotFound.Internal"). A *MultiError, whether err itself or
+// reached by unwrapping a wrapper such as WithStack, renders as a bracketed
+// sub-list of its aggregated errors' own status lines (e.g.
+// "Internal.[EOF, Timeout]") rather than being flattened into the dotted
+// chain, preserving the aggregation boundary.
 func StatusLine(err error) string {
 	if err == nil {
 		return ""
 	}
-	var s string
+	var parts []string
 	for err != nil {
-		switch t := err.(type) {
-		case *fundamental:
-			s += "." + string(t.code)
-		case *withMessage:
-			s += "." + string(t.code)
-		case *withStack:
-			s += "." + string(t.code)
-		}
-		cause, ok := err.(causer)
-		if !ok {
+		if m, ok := err.(*MultiError); ok {
+			sub := make([]string, len(m.errs))
+			for i, e := range m.errs {
+				sub[i] = StatusLine(e)
+			}
+			parts = append(parts, "["+strings.Join(sub, ", ")+"]")
 			break
 		}
-		err = cause.Cause()
+		if sw, ok := err.(statuser); ok {
+			if code := sw.Status(); code != "" {
+				parts = append(parts, string(code))
+			}
+		}
+		err = stderrors.Unwrap(err)
 	}
-	if s != "" {
-		return s[1:]
-	} else {
+	if len(parts) == 0 {
 		return string(Unknown)
 	}
+	return strings.Join(parts, ".")
+}
+
+// Is reports whether any error in err's chain matches target.
+//
+// If target is a status, Is returns true when any node in the chain
+// carries that status, equivalent to HasStatus(err, target). Otherwise
+// Is delegates to the standard library errors.Is.
+func Is(err, target error) bool {
+	if s, ok := target.(status); ok {
+		return HasStatus(err, s)
+	}
+	return stderrors.Is(err, target)
+}
+
+// As delegates to the standard library errors.As, finding the first
+// error in err's chain that matches target and setting target to it.
+func As(err error, target interface{}) bool {
+	return stderrors.As(err, target)
+}
+
+// HasStatus reports whether any error in err's chain carries status s.
+func HasStatus(err error, s status) bool {
+	found := false
+	walkChain(err, func(e error) bool {
+		if sw, ok := e.(statuser); ok && sw.Status() == s {
+			found = true
+			return true
+		}
+		return false
+	})
+	return found
 }
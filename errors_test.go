@@ -0,0 +1,78 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/king526/errors"
+)
+
+var (
+	eof     = errors.NewStatus("EOF")
+	timeout = errors.NewStatus("Timeout")
+)
+
+func Test_Unwrap(t *testing.T) {
+	root := errors.New(eof, "read failed")
+	wrapped := errors.WithStack(root, timeout)
+	wrapped = errors.Wrap(wrapped, errors.Unknown, "giving up")
+
+	if got := stderrors.Unwrap(stderrors.Unwrap(stderrors.Unwrap(wrapped))); got != nil {
+		t.Errorf("expected chain to end in nil, got %v", got)
+	}
+	if stderrors.Unwrap(wrapped) == nil {
+		t.Errorf("expected Wrap to unwrap to its cause")
+	}
+}
+
+func Test_Is(t *testing.T) {
+	root := errors.New(eof, "read failed")
+	wrapped := errors.WithStack(root, timeout)
+	wrapped = fmt.Errorf("context: %w", wrapped)
+
+	if !errors.Is(wrapped, eof) {
+		t.Errorf("expected chain to carry status %v", eof)
+	}
+	if !errors.Is(wrapped, timeout) {
+		t.Errorf("expected chain to carry status %v", timeout)
+	}
+	if errors.Is(wrapped, errors.NewStatus("Other")) {
+		t.Errorf("did not expect chain to carry an unrelated status")
+	}
+	if !stderrors.Is(wrapped, root) {
+		t.Errorf("expected stdlib errors.Is to still find root")
+	}
+}
+
+type customErr struct{ msg string }
+
+func (c *customErr) Error() string { return c.msg }
+
+func Test_As(t *testing.T) {
+	custom := &customErr{msg: "boom"}
+	wrapped := errors.Wrap(fmt.Errorf("wrap: %w", custom), eof, "outer")
+
+	var target *customErr
+	if !errors.As(wrapped, &target) {
+		t.Fatalf("expected As to find *customErr in the chain")
+	}
+	if target != custom {
+		t.Errorf("expected As to set target to the original error")
+	}
+}
+
+func Test_HasStatus(t *testing.T) {
+	root := errors.New(eof, "read failed")
+	wrapped := errors.Wrap(root, timeout, "retrying")
+
+	if !errors.HasStatus(wrapped, eof) {
+		t.Errorf("expected HasStatus to find %v in cause chain", eof)
+	}
+	if !errors.HasStatus(wrapped, timeout) {
+		t.Errorf("expected HasStatus to find %v on outer error", timeout)
+	}
+	if errors.HasStatus(wrapped, errors.NewStatus("Missing")) {
+		t.Errorf("did not expect HasStatus to find an absent status")
+	}
+}
@@ -0,0 +1,357 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// frameJSON is the wire representation of a single stack frame.
+type frameJSON struct {
+	Func string `json:"func,omitempty"`
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+// frameJSONFrom reads f's own name/file/line accessors directly, rather
+// than round-tripping through its human-readable %+v format.
+func frameJSONFrom(f Frame) frameJSON {
+	return frameJSON{Func: f.name(), File: f.file(), Line: f.line()}
+}
+
+// chainEntry is the wire representation of a single error in a chain, as
+// produced by Marshal and consumed by Unmarshal.
+type chainEntry struct {
+	Status  string      `json:"status,omitempty"`
+	Message string      `json:"message,omitempty"`
+	Stack   []frameJSON `json:"stack,omitempty"`
+	// Causer records whether the original node implemented causer (had a
+	// Cause() method), as opposed to only stdlib Unwrap (e.g. a plain
+	// fmt.Errorf("%w", ...)). Unmarshal uses it to decide whether Cause
+	// should stop at the reconstructed node or continue through it, so
+	// that Cause behaves identically on the round-tripped chain.
+	Causer bool `json:"causer,omitempty"`
+	// Multi is set instead of the fields above when this entry is the
+	// deepest node of the chain and that node was a *MultiError nested as
+	// someone else's cause (as opposed to being err itself, which Marshal
+	// renders as a top-level multiEnvelope). Each element is the Marshal
+	// output of one aggregated error, preserving the aggregation boundary
+	// at whatever depth it occurred.
+	Multi []json.RawMessage `json:"multi,omitempty"`
+}
+
+// entryFor builds the chainEntry for a single error node, without
+// following its cause. It backs both Marshal and the per-type
+// MarshalJSON methods below.
+func entryFor(e error) chainEntry {
+	var entry chainEntry
+	if sw, ok := e.(statuser); ok {
+		entry.Status = string(sw.Status())
+	}
+	if mw, ok := e.(messenger); ok {
+		entry.Message = mw.Message()
+	} else {
+		entry.Message = e.Error()
+	}
+	if st, ok := e.(stackTracer); ok {
+		for _, f := range st.StackTrace() {
+			entry.Stack = append(entry.Stack, frameJSONFrom(f))
+		}
+	}
+	_, entry.Causer = e.(causer)
+	return entry
+}
+
+// MarshalJSON renders f as a single chainEntry, without its cause (a
+// fundamental never has one).
+func (f *fundamental) MarshalJSON() ([]byte, error) { return json.Marshal(entryFor(f)) }
+
+// MarshalJSON renders w as a single chainEntry, without its cause; use
+// Marshal to render the full chain.
+func (w *withMessage) MarshalJSON() ([]byte, error) { return json.Marshal(entryFor(w)) }
+
+// MarshalJSON renders w, including its own stack trace, as a single
+// chainEntry, without its cause; use Marshal to render the full chain.
+func (w *withStack) MarshalJSON() ([]byte, error) { return json.Marshal(entryFor(w)) }
+
+// multiEnvelope is the wire representation of a *MultiError: each element
+// is itself the Marshal output of one aggregated error, so the MultiError
+// boundary survives the round trip instead of being flattened into an
+// ordinary chain.
+type multiEnvelope struct {
+	Multi []json.RawMessage `json:"multi"`
+}
+
+// Marshal renders err's entire chain as a JSON array, outer error first,
+// one entry per node. The deepest error, if it does not implement causer,
+// is rendered as a bare {"message": ...}. If err is a *MultiError, Marshal
+// instead renders a {"multi": [...]} envelope of each aggregated error's
+// own Marshal output, preserving the aggregation on the wire. A
+// *MultiError nested as the cause of an outer wrapper, rather than being
+// err itself, is rendered the same way but as the chain's last entry
+// (see chainEntry.Multi), so the boundary survives at any depth.
+func Marshal(err error) ([]byte, error) {
+	if m, ok := err.(*MultiError); ok {
+		branches, err := marshalBranches(m.errs)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(multiEnvelope{Multi: branches})
+	}
+	var chain []chainEntry
+	for cur := err; cur != nil; cur = stderrors.Unwrap(cur) {
+		if m, ok := cur.(*MultiError); ok {
+			branches, err := marshalBranches(m.errs)
+			if err != nil {
+				return nil, err
+			}
+			chain = append(chain, chainEntry{Multi: branches})
+			break
+		}
+		chain = append(chain, entryFor(cur))
+	}
+	return json.Marshal(chain)
+}
+
+// marshalBranches renders each of a MultiError's aggregated errors via
+// Marshal, for embedding in either a top-level multiEnvelope or a nested
+// chainEntry.Multi.
+func marshalBranches(errs []error) ([]json.RawMessage, error) {
+	branches := make([]json.RawMessage, len(errs))
+	for i, e := range errs {
+		data, err := Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		branches[i] = data
+	}
+	return branches, nil
+}
+
+// remoteFrame is an opaque stack frame reconstructed by Unmarshal. It
+// carries no program counter, only the func/file/line recorded in the
+// marshaled JSON, but still formats like a Frame under %+v.
+type remoteFrame struct {
+	fn   string
+	file string
+	line int
+}
+
+func (f remoteFrame) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		if s.Flag('+') {
+			io.WriteString(s, f.fn)
+			io.WriteString(s, "\n\t")
+			io.WriteString(s, f.file)
+			io.WriteString(s, ":")
+			io.WriteString(s, strconv.Itoa(f.line))
+			return
+		}
+		io.WriteString(s, f.fn)
+	}
+}
+
+// remoteLeaf is the error type reconstructed for the deepest entry of a
+// marshaled chain. Like fundamental, it has no cause and does not
+// implement causer, so Cause stops here instead of unwrapping through a
+// nil cause.
+type remoteLeaf struct {
+	code   status
+	msg    string
+	frames []remoteFrame
+}
+
+func (r *remoteLeaf) Error() string {
+	s := string(r.code)
+	if r.msg != "" {
+		if s != "" {
+			s += ":"
+		}
+		s += r.msg
+	}
+	return s
+}
+
+func (r *remoteLeaf) Status() status  { return r.code }
+func (r *remoteLeaf) Message() string { return r.msg }
+func (r *remoteLeaf) Unwrap() error   { return nil }
+
+func (r *remoteLeaf) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, r.Error())
+			for _, f := range r.frames {
+				io.WriteString(s, "\n")
+				f.Format(s, verb)
+			}
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, r.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", r.Error())
+	}
+}
+
+// remoteError is the error type reconstructed for every non-leaf entry of
+// a marshaled chain. It implements causer, Unwrap, statuser and messenger
+// identically to the wrapper types built by Wrap and WithStack, so
+// StatusLine, Cause, Is, As and HasStatus all behave the same on a
+// reconstructed chain.
+type remoteError struct {
+	code   status
+	msg    string
+	frames []remoteFrame
+	cause  error
+}
+
+func (r *remoteError) Error() string {
+	s := string(r.code)
+	if r.msg != "" {
+		if s != "" {
+			s += ":"
+		}
+		s += r.msg
+	}
+	return s + "; " + r.cause.Error()
+}
+
+func (r *remoteError) Cause() error    { return r.cause }
+func (r *remoteError) Unwrap() error   { return r.cause }
+func (r *remoteError) Status() status  { return r.code }
+func (r *remoteError) Message() string { return r.msg }
+
+func (r *remoteError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			fmt.Fprintf(s, "%s:%s", r.code, r.msg)
+			for _, f := range r.frames {
+				io.WriteString(s, "\n")
+				f.Format(s, verb)
+			}
+			fmt.Fprintf(s, "\n%+v", r.cause)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, r.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", r.Error())
+	}
+}
+
+// remoteWrap is the error type reconstructed for a non-leaf entry whose
+// original did not implement causer (e.g. a plain fmt.Errorf("%w", ...)).
+// Like that original, it implements Unwrap but not Cause, so Cause stops
+// at the first node that genuinely implemented causer, exactly as it
+// would have on the original chain.
+type remoteWrap struct {
+	code   status
+	msg    string
+	frames []remoteFrame
+	cause  error
+}
+
+func (r *remoteWrap) Error() string   { return r.msg }
+func (r *remoteWrap) Unwrap() error   { return r.cause }
+func (r *remoteWrap) Status() status  { return r.code }
+func (r *remoteWrap) Message() string { return r.msg }
+
+func (r *remoteWrap) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, r.msg)
+			for _, f := range r.frames {
+				io.WriteString(s, "\n")
+				f.Format(s, verb)
+			}
+			fmt.Fprintf(s, "\n%+v", r.cause)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, r.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", r.Error())
+	}
+}
+
+func framesFor(entry chainEntry) []remoteFrame {
+	frames := make([]remoteFrame, len(entry.Stack))
+	for i, f := range entry.Stack {
+		frames[i] = remoteFrame{fn: f.Func, file: f.File, line: f.Line}
+	}
+	return frames
+}
+
+// unmarshalBranches reconstructs each of a MultiError's aggregated errors
+// via Unmarshal, for either a top-level multiEnvelope or a nested
+// chainEntry.Multi.
+func unmarshalBranches(branches []json.RawMessage) ([]error, error) {
+	errs := make([]error, len(branches))
+	for i, branch := range branches {
+		e, err := Unmarshal(branch)
+		if err != nil {
+			return nil, err
+		}
+		errs[i] = e
+	}
+	return errs, nil
+}
+
+// Unmarshal reconstructs an error chain from JSON produced by Marshal,
+// such that StatusLine, Cause, Is, As and HasStatus behave identically on
+// the result. Stack frames become opaque remoteFrame values that still
+// format via %+v, but carry no real program counter. A {"multi": [...]}
+// envelope, as produced by Marshal for a *MultiError, reconstructs a
+// *MultiError aggregating each branch's own Unmarshal result; a
+// chainEntry.Multi on the deepest entry of an otherwise ordinary chain
+// does the same for a *MultiError nested as someone else's cause.
+func Unmarshal(data []byte) (error, error) {
+	var envelope multiEnvelope
+	if err := json.Unmarshal(data, &envelope); err == nil && envelope.Multi != nil {
+		errs, err := unmarshalBranches(envelope.Multi)
+		if err != nil {
+			return nil, err
+		}
+		return &MultiError{errs: errs}, nil
+	}
+
+	var chain []chainEntry
+	if err := json.Unmarshal(data, &chain); err != nil {
+		return nil, err
+	}
+	if len(chain) == 0 {
+		return nil, nil
+	}
+
+	var built error
+	for i := len(chain) - 1; i >= 0; i-- {
+		entry := chain[i]
+		if entry.Multi != nil {
+			errs, err := unmarshalBranches(entry.Multi)
+			if err != nil {
+				return nil, err
+			}
+			built = &MultiError{errs: errs}
+			continue
+		}
+		frames := framesFor(entry)
+		switch {
+		case built == nil:
+			built = &remoteLeaf{code: status(entry.Status), msg: entry.Message, frames: frames}
+		case entry.Causer:
+			built = &remoteError{code: status(entry.Status), msg: entry.Message, frames: frames, cause: built}
+		default:
+			built = &remoteWrap{code: status(entry.Status), msg: entry.Message, frames: frames, cause: built}
+		}
+	}
+	return built, nil
+}
@@ -0,0 +1,116 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/king526/errors"
+)
+
+// Test_MultiError_MarshalJSON_directly covers the same invariant as
+// Test_MarshalJSON_directly for the package's other error types, but for
+// *MultiError: json.Marshal on a bare *MultiError (not via errors.Marshal)
+// must not produce an empty {} object.
+func Test_MultiError_MarshalJSON_directly(t *testing.T) {
+	multi := errors.Append(nil, errors.New(eof, "1"), errors.New(timeout, "2"))
+
+	data, jsonErr := json.Marshal(multi)
+	if jsonErr != nil {
+		t.Fatalf("json.Marshal: %v", jsonErr)
+	}
+	if string(data) == "{}" {
+		t.Fatalf("json.Marshal produced an empty object")
+	}
+	if !strings.Contains(string(data), string(eof)) || !strings.Contains(string(data), string(timeout)) {
+		t.Errorf("json.Marshal output %s does not carry both statuses", data)
+	}
+}
+
+func Test_Marshal_Unmarshal_roundtrip(t *testing.T) {
+	root := errors.New(eof, "read failed")
+	wrapped := errors.WithStack(root, timeout)
+	wrapped = errors.Wrap(wrapped, errors.Unknown, "giving up")
+
+	data, err := errors.Marshal(wrapped)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	got, err := errors.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if want, have := errors.StatusLine(wrapped), errors.StatusLine(got); want != have {
+		t.Errorf("StatusLine = %q, want %q", have, want)
+	}
+	if !errors.Is(got, eof) {
+		t.Errorf("expected reconstructed chain to carry status %v", eof)
+	}
+	if !errors.Is(got, timeout) {
+		t.Errorf("expected reconstructed chain to carry status %v", timeout)
+	}
+	if errors.Cause(got).Error() != errors.Cause(wrapped).Error() {
+		t.Errorf("Cause mismatch after round trip")
+	}
+	if !strings.Contains(fmt.Sprintf("%+v", got), "read failed") {
+		t.Errorf("expected %%+v of reconstructed chain to contain the original message")
+	}
+}
+
+// Test_Marshal_Unmarshal_roundtrip_stdlibWrapped covers a chain mixing a
+// package wrapper with a plain stdlib fmt.Errorf("%w", ...) error: the
+// reconstructed chain must report the same StatusLine (no trailing "." for
+// the status-less stdlib node) and the same Cause (which must stop at the
+// stdlib node, since it has Unwrap but not Cause).
+func Test_Marshal_Unmarshal_roundtrip_stdlibWrapped(t *testing.T) {
+	wrapped := errors.WithStack(fmt.Errorf("wrap: %w", io.EOF), timeout)
+
+	data, err := errors.Marshal(wrapped)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	got, err := errors.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if want, have := errors.StatusLine(wrapped), errors.StatusLine(got); want != have {
+		t.Errorf("StatusLine = %q, want %q", have, want)
+	}
+	if want, have := errors.Cause(wrapped).Error(), errors.Cause(got).Error(); want != have {
+		t.Errorf("Cause = %q, want %q", have, want)
+	}
+	if !errors.Is(got, timeout) {
+		t.Errorf("expected reconstructed chain to carry status %v", timeout)
+	}
+}
+
+// Test_MarshalJSON_directly covers the literal ask: json.Marshal on a
+// fundamental, withMessage or withStack value directly (not via
+// errors.Marshal) must not produce an empty {} object.
+func Test_MarshalJSON_directly(t *testing.T) {
+	root := errors.New(eof, "read failed")
+	withStack := errors.WithStack(root, timeout)
+	withMessage := errors.Wrap(root, timeout, "retrying")
+
+	for name, err := range map[string]error{
+		"fundamental": root,
+		"withStack":   withStack,
+		"withMessage": withMessage,
+	} {
+		data, jsonErr := json.Marshal(err)
+		if jsonErr != nil {
+			t.Fatalf("%s: json.Marshal: %v", name, jsonErr)
+		}
+		if string(data) == "{}" {
+			t.Errorf("%s: json.Marshal produced an empty object", name)
+		}
+		if !strings.Contains(string(data), string(eof)) && !strings.Contains(string(data), string(timeout)) {
+			t.Errorf("%s: json.Marshal output %s carries no status", name, data)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MultiError aggregates multiple errors behind a single error value. It
+// satisfies causer, returning the first error appended to it, and
+// Unwrap() []error, so the standard library errors.Is, errors.As and
+// errors.Join all understand it.
+type MultiError struct {
+	errs []error
+}
+
+// Append returns a MultiError aggregating err and errs, flattening any of
+// them that are themselves a *MultiError. Nil errors are dropped. Append
+// returns nil if the result would be empty, so it is safe to call
+// unconditionally in a fan-out loop:
+//
+//	var result error
+//	for _, task := range tasks {
+//	        if err := task(); err != nil {
+//	                result = errors.Append(result, err)
+//	        }
+//	}
+func Append(err error, errs ...error) error {
+	var base []error
+	if m, ok := err.(*MultiError); ok {
+		base = m.errs
+	} else if err != nil {
+		base = []error{err}
+	}
+	merged := make([]error, len(base), len(base)+len(errs))
+	copy(merged, base)
+	for _, e := range errs {
+		if e == nil {
+			continue
+		}
+		if em, ok := e.(*MultiError); ok {
+			merged = append(merged, em.errs...)
+			continue
+		}
+		merged = append(merged, e)
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return &MultiError{errs: merged}
+}
+
+// Errors returns the errors aggregated by m.
+func (m *MultiError) Errors() []error { return m.errs }
+
+func (m *MultiError) Error() string {
+	if len(m.errs) == 1 {
+		return m.errs[0].Error()
+	}
+	msgs := make([]string, len(m.errs))
+	for i, e := range m.errs {
+		msgs[i] = e.Error()
+	}
+	return fmt.Sprintf("%d errors occurred: [%s]", len(m.errs), strings.Join(msgs, "; "))
+}
+
+// Cause returns the first error aggregated by m.
+func (m *MultiError) Cause() error {
+	if len(m.errs) == 0 {
+		return nil
+	}
+	return m.errs[0]
+}
+
+// Unwrap returns all errors aggregated by m, for use by the standard
+// library errors.Is, errors.As and errors.Join.
+func (m *MultiError) Unwrap() []error { return m.errs }
+
+// MarshalJSON renders m as a {"multi": [...]} envelope of each aggregated
+// error's own Marshal output, the same representation Marshal produces for
+// a *MultiError; use Marshal(m) directly to avoid the round-trip through
+// the json package.
+func (m *MultiError) MarshalJSON() ([]byte, error) {
+	branches, err := marshalBranches(m.errs)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(multiEnvelope{Multi: branches})
+}
+
+func (m *MultiError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			fmt.Fprintf(s, "%d errors occurred:", len(m.errs))
+			for i, e := range m.errs {
+				indented := strings.ReplaceAll(fmt.Sprintf("%+v", e), "\n", "\n    ")
+				fmt.Fprintf(s, "\n  * [%d] %s\n    %s", i+1, StatusLine(e), indented)
+			}
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, m.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", m.Error())
+	}
+}
@@ -0,0 +1,166 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/king526/errors"
+)
+
+func Test_Append(t *testing.T) {
+	if err := errors.Append(nil); err != nil {
+		t.Errorf("Append with no errors = %v, want nil", err)
+	}
+
+	e1 := errors.New(eof, "first")
+	e2 := errors.New(timeout, "second")
+	multi := errors.Append(nil, e1, e2)
+
+	me, ok := multi.(*errors.MultiError)
+	if !ok {
+		t.Fatalf("Append did not return a *MultiError")
+	}
+	if len(me.Errors()) != 2 {
+		t.Fatalf("len(Errors()) = %d, want 2", len(me.Errors()))
+	}
+	if errors.Cause(multi) != e1 {
+		t.Errorf("Cause(multi) = %v, want first appended error", errors.Cause(multi))
+	}
+
+	flattened := errors.Append(multi, errors.New(errors.Unknown, "third"))
+	if len(flattened.(*errors.MultiError).Errors()) != 3 {
+		t.Errorf("expected Append to flatten a nested *MultiError")
+	}
+}
+
+// Test_Append_concurrentSharedBase covers the fan-out use case Append is
+// pitched for: many goroutines appending onto the same base *MultiError
+// concurrently must not race or mutate each other's view of base. Append
+// must treat base as immutable and return a fresh *MultiError per call.
+func Test_Append_concurrentSharedBase(t *testing.T) {
+	base := errors.Append(nil, errors.New(eof, "0"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errors.Append(base, errors.New(timeout, fmt.Sprint(i)))
+		}(i)
+	}
+	wg.Wait()
+
+	if got := len(base.(*errors.MultiError).Errors()); got != 1 {
+		t.Errorf("len(base.Errors()) = %d, want 1 (base must not be mutated by Append)", got)
+	}
+}
+
+func Test_MultiError_StatusLine(t *testing.T) {
+	multi := errors.Append(nil, errors.New(eof, "1"), errors.New(timeout, "2"))
+	want := fmt.Sprintf("[%s, %s]", eof, timeout)
+	if got := errors.StatusLine(multi); got != want {
+		t.Errorf("StatusLine(multi) = %q, want %q", got, want)
+	}
+}
+
+func Test_MultiError_stdlib(t *testing.T) {
+	e1 := errors.New(eof, "1")
+	e2 := errors.New(timeout, "2")
+	multi := errors.Append(nil, e1, e2)
+
+	if !stderrors.Is(multi, e1) || !stderrors.Is(multi, e2) {
+		t.Errorf("expected stdlib errors.Is to see every aggregated error")
+	}
+	if !strings.Contains(fmt.Sprintf("%+v", multi), "1") {
+		t.Errorf("expected %%+v to include the first error's status chain")
+	}
+}
+
+// Test_MultiError_MarshalUnmarshal_preservesBoundary covers the wire
+// representation: Marshal must not flatten a *MultiError into an ordinary
+// chain, or its aggregation boundary is lost and StatusLine's bracketed
+// form can't be reconstructed.
+func Test_MultiError_MarshalUnmarshal_preservesBoundary(t *testing.T) {
+	multi := errors.Append(nil, errors.New(eof, "1"), errors.New(timeout, "2"))
+
+	data, err := errors.Marshal(multi)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	got, err := errors.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if _, ok := got.(*errors.MultiError); !ok {
+		t.Fatalf("Unmarshal did not reconstruct a *MultiError")
+	}
+	if want, have := errors.StatusLine(multi), errors.StatusLine(got); want != have {
+		t.Errorf("StatusLine(roundtrip) = %q, want %q", have, want)
+	}
+}
+
+// Test_MultiError_MarshalUnmarshal_preservesBoundary_nested covers a
+// *MultiError nested as the cause of an outer wrapper, rather than being
+// err itself: its aggregation boundary must survive the round trip too,
+// not just at the top level.
+func Test_MultiError_MarshalUnmarshal_preservesBoundary_nested(t *testing.T) {
+	multi := errors.Append(nil, errors.New(eof, "1"), errors.New(timeout, "2"))
+	wrapped := errors.WithStack(multi, errors.Unknown, "giving up")
+
+	data, err := errors.Marshal(wrapped)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	got, err := errors.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if want, have := errors.StatusLine(wrapped), errors.StatusLine(got); want != have {
+		t.Errorf("StatusLine(roundtrip) = %q, want %q", have, want)
+	}
+	if want, have := errors.Cause(wrapped).Error(), errors.Cause(got).Error(); want != have {
+		t.Errorf("Cause(roundtrip) = %q, want %q", have, want)
+	}
+}
+
+// Test_StatusLine_nestedMultiError covers a *MultiError reached by
+// unwrapping, not just err itself: its branches must render as a bracketed
+// sub-list rather than being flattened into the outer dotted chain.
+func Test_StatusLine_nestedMultiError(t *testing.T) {
+	multi := errors.Append(nil, errors.New(eof, "1"), errors.New(timeout, "2"))
+	wrapped := errors.WithStack(multi, errors.Unknown, "giving up")
+
+	want := "Unknown.[EOF, Timeout]"
+	if have := errors.StatusLine(wrapped); have != want {
+		t.Errorf("StatusLine(wrapped) = %q, want %q", have, want)
+	}
+}
+
+// Test_MultiError_statusAware covers every status-aware entry point built
+// by the earlier requests, not just StatusLine: HasStatus and Marshal
+// must see into a MultiError's aggregated errors too.
+func Test_MultiError_statusAware(t *testing.T) {
+	e1 := errors.New(eof, "1")
+	e2 := errors.New(timeout, "2")
+	multi := errors.Append(nil, e1, e2)
+
+	if !errors.HasStatus(multi, eof) {
+		t.Errorf("expected HasStatus(multi, %v) to be true", eof)
+	}
+	if !errors.HasStatus(multi, timeout) {
+		t.Errorf("expected HasStatus(multi, %v) to be true", timeout)
+	}
+
+	data, err := errors.Marshal(multi)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(data), string(eof)) || !strings.Contains(string(data), string(timeout)) {
+		t.Errorf("Marshal(multi) = %s, want it to carry both aggregated statuses", data)
+	}
+}
@@ -0,0 +1,227 @@
+package errors
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Severity classifies how serious an error is, independent of its status.
+type Severity int
+
+const (
+	// SeverityError is the default severity for a status that has not
+	// been registered with an explicit severity.
+	SeverityError Severity = iota
+	SeverityDebug
+	SeverityInfo
+	SeverityWarning
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityDebug:
+		return "Debug"
+	case SeverityInfo:
+		return "Info"
+	case SeverityWarning:
+		return "Warning"
+	case SeverityCritical:
+		return "Critical"
+	default:
+		return "Error"
+	}
+}
+
+const (
+	defaultHTTPCode = 500
+	// defaultGRPCCode is the numeric value of grpc-go's codes.Unknown.
+	//
+	// WithGRPCCode and GRPCCode deliberately use a plain int rather than
+	// grpc-go's codes.Code, so this package has no dependency on grpc-go.
+	// This is a public-API deviation from a codes.Code-typed signature:
+	// callers that do depend on grpc-go must convert at the boundary,
+	// e.g. codes.Code(errors.GRPCCode(err)) and
+	// errors.WithGRPCCode(int(codes.NotFound)).
+	defaultGRPCCode = 2
+	defaultSeverity = SeverityError
+)
+
+// statusMeta holds the metadata registered for a single status. A nil
+// field means "not set", so that RegisterStatus can tell an unset field
+// apart from one explicitly set to its zero value.
+type statusMeta struct {
+	httpCode    *int
+	grpcCode    *int
+	severity    *Severity
+	description string
+}
+
+func (m *statusMeta) equal(o *statusMeta) bool {
+	if (m.httpCode == nil) != (o.httpCode == nil) {
+		return false
+	}
+	if m.httpCode != nil && *m.httpCode != *o.httpCode {
+		return false
+	}
+	if (m.grpcCode == nil) != (o.grpcCode == nil) {
+		return false
+	}
+	if m.grpcCode != nil && *m.grpcCode != *o.grpcCode {
+		return false
+	}
+	if (m.severity == nil) != (o.severity == nil) {
+		return false
+	}
+	if m.severity != nil && *m.severity != *o.severity {
+		return false
+	}
+	return m.description == o.description
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*statusMeta{}
+)
+
+// StatusOption configures the metadata attached to a status by
+// RegisterStatus.
+type StatusOption func(*statusMeta)
+
+// WithHTTPCode attaches an HTTP status code to a registered status.
+func WithHTTPCode(code int) StatusOption {
+	return func(m *statusMeta) { m.httpCode = &code }
+}
+
+// WithGRPCCode attaches a gRPC status code to a registered status. It
+// takes a plain int rather than grpc-go's codes.Code so that this
+// package stays dependency-free; pass int(codes.NotFound) and friends.
+// See the defaultGRPCCode comment for why this departs from a
+// codes.Code-typed signature.
+func WithGRPCCode(code int) StatusOption {
+	return func(m *statusMeta) { m.grpcCode = &code }
+}
+
+// WithSeverity attaches a severity level to a registered status.
+func WithSeverity(s Severity) StatusOption {
+	return func(m *statusMeta) { m.severity = &s }
+}
+
+// WithDescription attaches a human-readable description to a registered
+// status.
+func WithDescription(desc string) StatusOption {
+	return func(m *statusMeta) { m.description = desc }
+}
+
+// RegisterStatus registers name with the given metadata and returns the
+// corresponding status, so that HTTPCode, GRPCCode, SeverityOf and
+// Description can translate any error carrying it without a type switch.
+//
+// RegisterStatus is goroutine-safe. Registering the same name twice is
+// only allowed if the metadata is identical; otherwise RegisterStatus
+// panics, since two conflicting registrations almost always indicate a
+// copy-pasted status name.
+func RegisterStatus(name string, opts ...StatusOption) status {
+	s := NewStatus(name)
+
+	meta := &statusMeta{}
+	for _, opt := range opts {
+		opt(meta)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if existing, ok := registry[name]; ok {
+		if !existing.equal(meta) {
+			panic(fmt.Sprintf("errors: status %q already registered with conflicting metadata", name))
+		}
+		return s
+	}
+	registry[name] = meta
+	return s
+}
+
+// lookupMeta walks err's chain outermost-first, calling pick for every
+// status it carries that has been registered. It stops as soon as pick
+// returns true.
+func lookupMeta(err error, pick func(*statusMeta) bool) {
+	walkChain(err, func(e error) bool {
+		sw, ok := e.(statuser)
+		if !ok {
+			return false
+		}
+		registryMu.RLock()
+		meta, ok := registry[string(sw.Status())]
+		registryMu.RUnlock()
+		if !ok {
+			return false
+		}
+		return pick(meta)
+	})
+}
+
+// HTTPCode returns the HTTP status code registered for the outermost
+// status in err's chain that has one, falling back to inner statuses and
+// finally to 500 if none was registered.
+func HTTPCode(err error) int {
+	code := defaultHTTPCode
+	lookupMeta(err, func(m *statusMeta) bool {
+		if m.httpCode == nil {
+			return false
+		}
+		code = *m.httpCode
+		return true
+	})
+	return code
+}
+
+// GRPCCode returns the gRPC status code registered for the outermost
+// status in err's chain that has one, falling back to inner statuses and
+// finally to the numeric value of codes.Unknown if none was registered.
+// It returns a plain int, not grpc-go's codes.Code; see the
+// defaultGRPCCode comment.
+func GRPCCode(err error) int {
+	code := defaultGRPCCode
+	lookupMeta(err, func(m *statusMeta) bool {
+		if m.grpcCode == nil {
+			return false
+		}
+		code = *m.grpcCode
+		return true
+	})
+	return code
+}
+
+// SeverityOf returns the severity registered for the outermost status in
+// err's chain that has one, falling back to inner statuses and finally to
+// SeverityError if none was registered.
+//
+// It is named SeverityOf, not Severity, because the Severity type already
+// claims that identifier; Go does not allow a function and a type to
+// share a name in the same package.
+func SeverityOf(err error) Severity {
+	s := defaultSeverity
+	lookupMeta(err, func(m *statusMeta) bool {
+		if m.severity == nil {
+			return false
+		}
+		s = *m.severity
+		return true
+	})
+	return s
+}
+
+// Description returns the description registered for the outermost status
+// in err's chain that has one, falling back to inner statuses and finally
+// to "" if none was registered.
+func Description(err error) string {
+	desc := ""
+	lookupMeta(err, func(m *statusMeta) bool {
+		if m.description == "" {
+			return false
+		}
+		desc = m.description
+		return true
+	})
+	return desc
+}
@@ -0,0 +1,90 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/king526/errors"
+)
+
+// notFoundGRPCCode mirrors grpc-go's codes.NotFound without importing it.
+const notFoundGRPCCode = 5
+
+func Test_RegisterStatus(t *testing.T) {
+	notFound := errors.RegisterStatus("NotFound",
+		errors.WithHTTPCode(404),
+		errors.WithGRPCCode(notFoundGRPCCode),
+		errors.WithSeverity(errors.SeverityWarning),
+		errors.WithDescription("resource not found"),
+	)
+
+	err := errors.New(notFound, "user 42")
+	if got := errors.HTTPCode(err); got != 404 {
+		t.Errorf("HTTPCode = %d, want 404", got)
+	}
+	if got := errors.GRPCCode(err); got != notFoundGRPCCode {
+		t.Errorf("GRPCCode = %d, want %d", got, notFoundGRPCCode)
+	}
+	if got := errors.SeverityOf(err); got != errors.SeverityWarning {
+		t.Errorf("Severity = %v, want %v", got, errors.SeverityWarning)
+	}
+	if got := errors.Description(err); got != "resource not found" {
+		t.Errorf("Description = %q, want %q", got, "resource not found")
+	}
+
+	// Re-registering with identical metadata is allowed.
+	errors.RegisterStatus("NotFound",
+		errors.WithHTTPCode(404),
+		errors.WithGRPCCode(notFoundGRPCCode),
+		errors.WithSeverity(errors.SeverityWarning),
+		errors.WithDescription("resource not found"),
+	)
+}
+
+func Test_RegisterStatus_conflict(t *testing.T) {
+	errors.RegisterStatus("Conflicted", errors.WithHTTPCode(400))
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected conflicting re-registration to panic")
+		}
+	}()
+	errors.RegisterStatus("Conflicted", errors.WithHTTPCode(409))
+}
+
+func Test_HTTPCode_fallback(t *testing.T) {
+	unregistered := errors.NewStatus("Unregistered")
+	err := errors.New(unregistered, "boom")
+	if got := errors.HTTPCode(err); got != 500 {
+		t.Errorf("HTTPCode = %d, want default 500", got)
+	}
+	if got := errors.GRPCCode(err); got != 2 {
+		t.Errorf("GRPCCode = %d, want default 2 (codes.Unknown)", got)
+	}
+	if got := errors.SeverityOf(err); got != errors.SeverityError {
+		t.Errorf("Severity = %v, want default %v", got, errors.SeverityError)
+	}
+}
+
+func Test_HTTPCode_outerWins(t *testing.T) {
+	inner := errors.RegisterStatus("InnerConflict", errors.WithHTTPCode(400))
+	outer := errors.RegisterStatus("OuterConflict", errors.WithHTTPCode(503))
+
+	err := errors.Wrap(errors.New(inner, "bad input"), outer, "upstream failed")
+	if got := errors.HTTPCode(err); got != 503 {
+		t.Errorf("HTTPCode = %d, want outermost 503", got)
+	}
+}
+
+// Test_HTTPCode_multiErrorFirstWins covers a *MultiError: HTTPCode and the
+// other lookupMeta-based accessors must agree with Cause(multi), which
+// returns m.errs[0] — so they must return the first aggregated error's
+// metadata, not the last one walkChain happens to visit.
+func Test_HTTPCode_multiErrorFirstWins(t *testing.T) {
+	first := errors.RegisterStatus("FirstCode", errors.WithHTTPCode(400))
+	second := errors.RegisterStatus("SecondCode", errors.WithHTTPCode(503))
+
+	multi := errors.Append(nil, errors.New(first, "bad input"), errors.New(second, "unavailable"))
+	if got := errors.HTTPCode(multi); got != 400 {
+		t.Errorf("HTTPCode(multi) = %d, want first/outermost 400", got)
+	}
+}